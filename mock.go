@@ -6,7 +6,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
-	"sync"
+	"time"
 
 	exception "github.com/blendlabs/go-exception"
 )
@@ -16,53 +16,31 @@ type MockedResponse struct {
 	ResponseBody []byte
 	StatusCode   int
 	Error        error
+	Headers      http.Header
+	Trailer      http.Header
+	Delay        time.Duration
 }
 
 // MockedResponseGenerator is a function that returns a mocked response.
 type MockedResponseGenerator func() MockedResponse
 
-var (
-	isMocked  bool
-	mocksLock sync.Mutex
-	mocks     map[string]MockedResponseGenerator
-)
+// DefaultMocker is the package-level `Mocker` used by the free functions below
+// (`MockResponse`, `MockError`, etc.) so existing callers keep working unchanged.
+var DefaultMocker = NewMocker()
 
 // MockedResponseInjector injects the mocked response into the request response.
 func MockedResponseInjector(verb string, workingURL *url.URL) (bool, *ResponseMeta, []byte, error) {
-	if isMocked {
-		mocksLock.Lock()
-		storedURL := fmt.Sprintf("%s_%s", verb, workingURL.String())
-		mockResponseHandler, ok := mocks[storedURL]
-		mocksLock.Unlock()
-		if ok {
-			mockResponse := mockResponseHandler()
-			meta := &ResponseMeta{}
-			meta.StatusCode = mockResponse.StatusCode
-			meta.ContentLength = int64(len(mockResponse.ResponseBody))
-			return true, meta, mockResponse.ResponseBody, mockResponse.Error
-		}
-		panic(fmt.Sprintf("attempted to make service request w/o mocking endpoint: %s %s", verb, workingURL.String()))
-	} else {
-		return false, nil, nil, nil
-	}
+	return DefaultMocker.inject(verb, workingURL)
 }
 
 // MockResponse mocks are response with a given generator.
 func MockResponse(verb string, url string, gen MockedResponseGenerator) {
-	mocksLock.Lock()
-	defer mocksLock.Unlock()
-
-	MockResponseUnsafe(verb, url, gen)
+	DefaultMocker.RegisterResponder(verb, url, gen)
 }
 
 // MockResponseUnsafe mocks are response with a given generator without locking.
 func MockResponseUnsafe(verb string, url string, gen MockedResponseGenerator) {
-	isMocked = true
-	if mocks == nil {
-		mocks = map[string]MockedResponseGenerator{}
-	}
-	storedURL := fmt.Sprintf("%s_%s", verb, url)
-	mocks[storedURL] = gen
+	DefaultMocker.registerResponderUnsafe(verb, url, gen)
 }
 
 // MockResponseFromBinary mocks a service request response from a set of binary responses.
@@ -153,9 +131,10 @@ func MockErrorUnsafe(verb string, url string) {
 
 // ClearMockedResponses clears any mocked responses that have been set up for the test.
 func ClearMockedResponses() {
-	mocksLock.Lock()
-	defer mocksLock.Unlock()
+	DefaultMocker.Reset()
+}
 
-	isMocked = false
-	mocks = map[string]MockedResponseGenerator{}
+// mockedKey builds the registry key for an exact verb/url responder.
+func mockedKey(verb, url string) string {
+	return fmt.Sprintf("%s_%s", verb, url)
 }
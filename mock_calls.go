@@ -0,0 +1,133 @@
+package request
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// MockCall records a single invocation of a mocked responder.
+type MockCall struct {
+	Verb      string
+	URL       string
+	Header    http.Header
+	Body      []byte
+	Timestamp time.Time
+}
+
+// TestingT is the subset of `*testing.T` the `AssertMockCalled*` helpers need.
+// It lets callers pass `*testing.T` without this package importing `testing` directly.
+type TestingT interface {
+	Errorf(format string, args ...interface{})
+}
+
+// GetMockCalls returns the recorded calls for a given verb/url on `DefaultMocker`, in call order.
+func GetMockCalls(verb string, url string) []MockCall {
+	return DefaultMocker.GetMockCalls(verb, url)
+}
+
+// GetTotalMockCalls returns the total number of recorded calls across all responders on `DefaultMocker`.
+func GetTotalMockCalls() int {
+	return DefaultMocker.GetTotalMockCalls()
+}
+
+// AssertMockCalled fails the test via `t` if `verb`/`url` was never called on `DefaultMocker`.
+func AssertMockCalled(t TestingT, verb string, url string) bool {
+	return DefaultMocker.AssertMockCalled(t, verb, url)
+}
+
+// AssertMockCalledN fails the test via `t` if `verb`/`url` was not called exactly `n` times on `DefaultMocker`.
+func AssertMockCalledN(t TestingT, verb string, url string, n int) bool {
+	return DefaultMocker.AssertMockCalledN(t, verb, url, n)
+}
+
+// GetMockCalls returns the recorded calls for a given verb/url, in call order.
+func (m *Mocker) GetMockCalls(verb string, url string) []MockCall {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return append([]MockCall{}, m.calls[mockedKey(verb, url)]...)
+}
+
+// GetTotalMockCalls returns the total number of recorded calls across all responders.
+func (m *Mocker) GetTotalMockCalls() int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	total := 0
+	for _, calls := range m.calls {
+		total += len(calls)
+	}
+	return total
+}
+
+// AssertMockCalled fails the test via `t` if `verb`/`url` was never called.
+func (m *Mocker) AssertMockCalled(t TestingT, verb string, url string) bool {
+	if len(m.GetMockCalls(verb, url)) == 0 {
+		t.Errorf("request#Mocker: expected %s %s to have been called, it was not", verb, url)
+		return false
+	}
+	return true
+}
+
+// AssertMockCalledN fails the test via `t` if `verb`/`url` was not called exactly `n` times.
+func (m *Mocker) AssertMockCalledN(t TestingT, verb string, url string, n int) bool {
+	calls := len(m.GetMockCalls(verb, url))
+	if calls != n {
+		t.Errorf("request#Mocker: expected %s %s to have been called %d time(s), it was called %d time(s)", verb, url, n, calls)
+		return false
+	}
+	return true
+}
+
+// recordCall appends a `MockCall` to the call log for `storedURL`, snapshotting the request body
+// so it can be inspected after the fact without disturbing subsequent reads of `req.Body`.
+func (m *Mocker) recordCall(storedURL string, req *http.Request) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = ioutil.ReadAll(req.Body)
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	if m.calls == nil {
+		m.calls = map[string][]MockCall{}
+	}
+	m.calls[storedURL] = append(m.calls[storedURL], MockCall{
+		Verb:      req.Method,
+		URL:       req.URL.String(),
+		Header:    req.Header,
+		Body:      body,
+		Timestamp: time.Now().UTC(),
+	})
+}
+
+// MockResponseOnce registers a responder on `DefaultMocker` that returns `response` for the first
+// call to `verb`/`url` and panics on any subsequent call, matching the exhaustible-responder
+// pattern used by `MockResponseTimes`.
+func MockResponseOnce(verb string, url string, response MockedResponse) {
+	DefaultMocker.RegisterResponder(verb, url, timesGenerator(verb, url, 1, response))
+}
+
+// MockResponseTimes registers a responder on `DefaultMocker` that returns `response` for the first
+// `n` calls to `verb`/`url` and panics once exhausted.
+func MockResponseTimes(verb string, url string, n int, response MockedResponse) {
+	DefaultMocker.RegisterResponder(verb, url, timesGenerator(verb, url, n, response))
+}
+
+func timesGenerator(verb string, url string, n int, response MockedResponse) MockedResponseGenerator {
+	remaining := n
+	var lock sync.Mutex
+	return func() MockedResponse {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if remaining <= 0 {
+			panic(fmt.Sprintf("request#Mocker: responder for %s %s exhausted its %d allotted call(s)", verb, url, n))
+		}
+		remaining--
+		return response
+	}
+}
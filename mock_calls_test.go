@@ -0,0 +1,55 @@
+package request
+
+import (
+	"net/url"
+	"testing"
+)
+
+type fakeT struct {
+	failed bool
+}
+
+func (f *fakeT) Errorf(format string, args ...interface{}) {
+	f.failed = true
+}
+
+func TestRecordCallCapturesHeaderAndBodyThroughExecute(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterResponder("POST", workingURL.String(), func() MockedResponse {
+		return MockedResponse{StatusCode: 201, ResponseBody: []byte("created")}
+	})
+
+	hr := NewHTTPRequest().WithVerb("POST").WithURL(workingURL).
+		WithHeader("X-Api-Key", "secret").
+		WithPostBody([]byte(`{"name":"widget"}`)).
+		WithMocker(mocker)
+
+	if _, _, err := hr.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	calls := mocker.GetMockCalls("POST", workingURL.String())
+	if len(calls) != 1 {
+		t.Fatalf("expected 1 recorded call, got %d", len(calls))
+	}
+	call := calls[0]
+	if call.Header.Get("X-Api-Key") != "secret" {
+		t.Fatalf("expected recorded header X-Api-Key=secret, got %q", call.Header.Get("X-Api-Key"))
+	}
+	if string(call.Body) != `{"name":"widget"}` {
+		t.Fatalf("expected recorded body %q, got %q", `{"name":"widget"}`, call.Body)
+	}
+
+	ft := &fakeT{}
+	mocker.AssertMockCalledN(ft, "POST", workingURL.String(), 1)
+	if ft.failed {
+		t.Fatalf("expected AssertMockCalledN to pass")
+	}
+
+	mocker.AssertMockCalledN(ft, "POST", workingURL.String(), 2)
+	if !ft.failed {
+		t.Fatalf("expected AssertMockCalledN to fail on a wrong count")
+	}
+}
@@ -0,0 +1,154 @@
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func durationFromMillis(ms int) time.Duration {
+	return time.Duration(ms) * time.Millisecond
+}
+
+// FixtureRequest describes the request half of a mock fixture.
+type FixtureRequest struct {
+	Method  string            `json:"method" yaml:"method"`
+	URL     string            `json:"url" yaml:"url"`
+	Query   map[string]string `json:"query,omitempty" yaml:"query,omitempty"`
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string            `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// FixtureResponse describes the canned response half of a mock fixture.
+type FixtureResponse struct {
+	Status   int               `json:"status" yaml:"status"`
+	Headers  map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body     string            `json:"body,omitempty" yaml:"body,omitempty"`
+	BodyFile string            `json:"body_file,omitempty" yaml:"body_file,omitempty"`
+	DelayMS  int               `json:"delay_ms,omitempty" yaml:"delay_ms,omitempty"`
+}
+
+// Fixture is a single request/response pair as loaded from a fixture file.
+type Fixture struct {
+	Request  FixtureRequest  `json:"request" yaml:"request"`
+	Response FixtureResponse `json:"response" yaml:"response"`
+}
+
+// LoadMocksFromDir reads every `.json`, `.yaml`, and `.yml` fixture file in `dir` (non-recursive)
+// and registers the mocks it describes on `DefaultMocker`.
+func LoadMocksFromDir(dir string) error {
+	return DefaultMocker.LoadMocksFromDir(dir)
+}
+
+// LoadMocksFromFile reads a single fixture file and registers the mocks it describes on `DefaultMocker`.
+func LoadMocksFromFile(path string) error {
+	return DefaultMocker.LoadMocksFromFile(path)
+}
+
+// LoadMocksFromDir reads every `.json`, `.yaml`, and `.yml` fixture file in `dir` (non-recursive)
+// and registers the mocks it describes on `m`.
+func (m *Mocker) LoadMocksFromDir(dir string) error {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".json", ".yaml", ".yml":
+			if err := m.LoadMocksFromFile(filepath.Join(dir, entry.Name())); err != nil {
+				return fmt.Errorf("request#Mocker: error loading fixture %s: %v", entry.Name(), err)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadMocksFromFile reads a single fixture file and registers the mocks it describes on `m`.
+func (m *Mocker) LoadMocksFromFile(path string) error {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var fixtures []Fixture
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contents, &fixtures)
+	default:
+		err = json.Unmarshal(contents, &fixtures)
+	}
+	if err != nil {
+		return fmt.Errorf("request#Mocker: error parsing fixture %s: %v", path, err)
+	}
+
+	for _, fixture := range fixtures {
+		if err := m.registerFixture(path, fixture); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Mocker) registerFixture(sourcePath string, fixture Fixture) error {
+	gen, err := fixtureGenerator(sourcePath, fixture.Response)
+	if err != nil {
+		return err
+	}
+
+	matchers := []RequestMatcher{MatchURLRegexp(regexp.MustCompile("^" + regexp.QuoteMeta(fixture.Request.URL) + "$"))}
+	if len(fixture.Request.Query) > 0 {
+		matchers = append(matchers, MatchQuery(fixture.Request.Query))
+	}
+	for header, valuePattern := range fixture.Request.Headers {
+		matchers = append(matchers, MatchHeader(header, regexp.MustCompile(valuePattern)))
+	}
+	if fixture.Request.Body != "" {
+		var expected interface{}
+		if err := json.Unmarshal([]byte(fixture.Request.Body), &expected); err == nil {
+			matchers = append(matchers, MatchJSONBody(expected))
+		}
+	}
+
+	m.RegisterMatchedResponder(strings.ToUpper(fixture.Request.Method), MatchAll(matchers...), gen)
+	return nil
+}
+
+func fixtureGenerator(sourcePath string, response FixtureResponse) (MockedResponseGenerator, error) {
+	body := []byte(response.Body)
+	if response.BodyFile != "" {
+		bodyFilePath := response.BodyFile
+		if !filepath.IsAbs(bodyFilePath) {
+			bodyFilePath = filepath.Join(filepath.Dir(sourcePath), bodyFilePath)
+		}
+		contents, err := ioutil.ReadFile(bodyFilePath)
+		if err != nil {
+			return nil, err
+		}
+		body = contents
+	}
+
+	headers := http.Header{}
+	for key, value := range response.Headers {
+		headers.Set(key, value)
+	}
+
+	return func() MockedResponse {
+		return MockedResponse{
+			StatusCode:   response.Status,
+			ResponseBody: body,
+			Headers:      headers,
+			Delay:        durationFromMillis(response.DelayMS),
+		}
+	}, nil
+}
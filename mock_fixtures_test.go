@@ -0,0 +1,83 @@
+package request
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadMocksFromFileMatchesHeaderAndBodyThroughExecute(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-request-fixtures")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fixturePath := filepath.Join(dir, "widgets.json")
+	fixture := `[
+		{
+			"request": {
+				"method": "POST",
+				"url": "http://example.com/widgets",
+				"headers": {"X-Api-Key": "^secret$"},
+				"body": "{\"name\":\"widget\"}"
+			},
+			"response": {
+				"status": 201,
+				"body": "created"
+			}
+		}
+	]`
+	if err := ioutil.WriteFile(fixturePath, []byte(fixture), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mocker := NewMocker()
+	if err := mocker.LoadMocksFromFile(fixturePath); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	workingURL, _ := url.Parse("http://example.com/widgets")
+	hr := NewHTTPRequest().WithVerb("POST").WithURL(workingURL).
+		WithHeader("X-Api-Key", "secret").
+		WithPostBody([]byte(`{"name":"widget"}`)).
+		WithMocker(mocker)
+
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 201 || string(body) != "created" {
+		t.Fatalf("expected fixture to match, got status=%d body=%q", meta.StatusCode, body)
+	}
+}
+
+func TestLoadMocksFromDir(t *testing.T) {
+	dir, err := ioutil.TempDir("", "go-request-fixtures-dir")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	fixture := `[{"request": {"method": "GET", "url": "http://example.com/widgets"}, "response": {"status": 200, "body": "ok"}}]`
+	if err := ioutil.WriteFile(filepath.Join(dir, "widgets.json"), []byte(fixture), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	mocker := NewMocker()
+	if err := mocker.LoadMocksFromDir(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	workingURL, _ := url.Parse("http://example.com/widgets")
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithMocker(mocker)
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 200 || string(body) != "ok" {
+		t.Fatalf("expected fixture to match, got status=%d body=%q", meta.StatusCode, body)
+	}
+}
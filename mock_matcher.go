@@ -0,0 +1,120 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+)
+
+// RequestMatcher matches an inbound `*http.Request` against arbitrary criteria,
+// letting a mocked responder cover a family of requests (dynamic ids, query
+// strings, headers, ...) instead of a single exact verb/url pair.
+type RequestMatcher interface {
+	Match(req *http.Request) bool
+}
+
+// RequestMatcherFunc adapts a function to a `RequestMatcher`.
+type RequestMatcherFunc func(req *http.Request) bool
+
+// Match implements `RequestMatcher`.
+func (f RequestMatcherFunc) Match(req *http.Request) bool {
+	return f(req)
+}
+
+// MatchURLRegexp matches requests whose url (as rendered by `url.URL.String()`) matches `pattern`.
+func MatchURLRegexp(pattern *regexp.Regexp) RequestMatcher {
+	return RequestMatcherFunc(func(req *http.Request) bool {
+		return pattern.MatchString(req.URL.String())
+	})
+}
+
+// MatchQuery matches requests whose query string contains every key/value pair in `query`.
+func MatchQuery(query map[string]string) RequestMatcher {
+	return RequestMatcherFunc(func(req *http.Request) bool {
+		values := req.URL.Query()
+		for key, expected := range query {
+			if values.Get(key) != expected {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// MatchHeader matches requests that carry a header named `name` whose value matches `valueRegexp`.
+func MatchHeader(name string, valueRegexp *regexp.Regexp) RequestMatcher {
+	return RequestMatcherFunc(func(req *http.Request) bool {
+		return valueRegexp.MatchString(req.Header.Get(name))
+	})
+}
+
+// MatchJSONBody matches requests whose body, unmarshalled as JSON, deep-equals `expected`
+// once `expected` itself has been round-tripped through JSON (so struct values and the
+// `map[string]interface{}` produced by decoding the request body compare equal).
+func MatchJSONBody(expected interface{}) RequestMatcher {
+	expectedBytes, expectedErr := json.Marshal(expected)
+	return RequestMatcherFunc(func(req *http.Request) bool {
+		if expectedErr != nil || req.Body == nil {
+			return false
+		}
+		bodyBytes, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return false
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+
+		var expectedNormalized, actualNormalized interface{}
+		if err := json.Unmarshal(expectedBytes, &expectedNormalized); err != nil {
+			return false
+		}
+		if err := json.Unmarshal(bodyBytes, &actualNormalized); err != nil {
+			return false
+		}
+		expectedJSON, _ := json.Marshal(expectedNormalized)
+		actualJSON, _ := json.Marshal(actualNormalized)
+		return bytes.Equal(expectedJSON, actualJSON)
+	})
+}
+
+// MatchAll combines matchers such that the composite only matches if every one of `matchers` matches.
+func MatchAll(matchers ...RequestMatcher) RequestMatcher {
+	return RequestMatcherFunc(func(req *http.Request) bool {
+		for _, matcher := range matchers {
+			if !matcher.Match(req) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// matchedResponder pairs a verb/matcher with the generator it should dispatch to.
+// Responders are tried in registration order.
+type matchedResponder struct {
+	verb    string
+	matcher RequestMatcher
+	gen     MockedResponseGenerator
+}
+
+// RegisterRegexpResponder registers a responder matched against the request url via `pattern`.
+func (m *Mocker) RegisterRegexpResponder(verb string, pattern *regexp.Regexp, gen MockedResponseGenerator) {
+	m.RegisterMatchedResponder(verb, MatchURLRegexp(pattern), gen)
+}
+
+// RegisterMatchedResponder registers a responder dispatched to `gen` whenever `matcher` matches
+// and no exact verb/url responder is registered for the request.
+func (m *Mocker) RegisterMatchedResponder(verb string, matcher RequestMatcher, gen MockedResponseGenerator) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.enabled = true
+	m.matchedResponders = append(m.matchedResponders, matchedResponder{verb: verb, matcher: matcher, gen: gen})
+}
+
+// MockResponseMatch registers a responder on `DefaultMocker` dispatched to `gen` whenever `matcher`
+// matches and no exact verb/url responder is registered for the request.
+func MockResponseMatch(verb string, matcher RequestMatcher, gen MockedResponseGenerator) {
+	DefaultMocker.RegisterMatchedResponder(verb, matcher, gen)
+}
@@ -0,0 +1,64 @@
+package request
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+)
+
+func TestMatchHeaderThroughExecute(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterMatchedResponder("GET", MatchHeader("X-Api-Key", regexp.MustCompile("^secret$")), func() MockedResponse {
+		return MockedResponse{StatusCode: 200, ResponseBody: []byte("matched")}
+	})
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithHeader("X-Api-Key", "secret").WithMocker(mocker)
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 200 || string(body) != "matched" {
+		t.Fatalf("expected matched response, got status=%d body=%q", meta.StatusCode, body)
+	}
+}
+
+func TestMatchHeaderThroughExecuteMismatch(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterMatchedResponder("GET", MatchHeader("X-Api-Key", regexp.MustCompile("^secret$")), func() MockedResponse {
+		return MockedResponse{StatusCode: 200, ResponseBody: []byte("matched")}
+	})
+	mocker.NoResponder(func() MockedResponse {
+		return MockedResponse{StatusCode: 401, ResponseBody: []byte("denied")}
+	})
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithHeader("X-Api-Key", "wrong").WithMocker(mocker)
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 401 || string(body) != "denied" {
+		t.Fatalf("expected NoResponder fallback, got status=%d body=%q", meta.StatusCode, body)
+	}
+}
+
+func TestMatchJSONBodyThroughExecute(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterMatchedResponder("POST", MatchJSONBody(map[string]interface{}{"name": "widget"}), func() MockedResponse {
+		return MockedResponse{StatusCode: 201, ResponseBody: []byte("created")}
+	})
+
+	hr := NewHTTPRequest().WithVerb("POST").WithURL(workingURL).WithPostBody([]byte(`{"name":"widget"}`)).WithMocker(mocker)
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 201 || string(body) != "created" {
+		t.Fatalf("expected matched response, got status=%d body=%q", meta.StatusCode, body)
+	}
+}
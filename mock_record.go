@@ -0,0 +1,124 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// RecordModeTransport wraps a real `http.RoundTripper` in a VCR-style cassette: the first time a
+// given verb/url is seen it performs the live call and writes the observed exchange to `CassettePath`,
+// and on every subsequent run it replays the recorded response instead of hitting the network.
+type RecordModeTransport struct {
+	Upstream     http.RoundTripper
+	CassettePath string
+	lock         sync.Mutex
+	loadedOnce   sync.Once
+	loadErr      error
+	cassette     []Fixture
+}
+
+// NewRecordModeTransport returns a `RecordModeTransport` that replays `cassettePath` if it exists
+// and records new exchanges into it (via `upstream`) as they're made.
+func NewRecordModeTransport(cassettePath string, upstream http.RoundTripper) *RecordModeTransport {
+	if upstream == nil {
+		upstream = http.DefaultTransport
+	}
+	return &RecordModeTransport{Upstream: upstream, CassettePath: cassettePath}
+}
+
+// RoundTrip implements `http.RoundTripper`.
+func (t *RecordModeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.loadedOnce.Do(func() {
+		t.cassette, t.loadErr = loadCassette(t.CassettePath)
+	})
+	if t.loadErr != nil {
+		return nil, t.loadErr
+	}
+
+	t.lock.Lock()
+	fixture, ok := findCassetteFixture(t.cassette, req)
+	t.lock.Unlock()
+	if ok {
+		gen, err := fixtureGenerator(t.CassettePath, fixture.Response)
+		if err != nil {
+			return nil, err
+		}
+		mockResponse := gen()
+		return &http.Response{
+			StatusCode: mockResponse.StatusCode,
+			Header:     mockResponse.Headers,
+			Body:       ioutil.NopCloser(bytes.NewReader(mockResponse.ResponseBody)),
+			Request:    req,
+		}, nil
+	}
+
+	response, err := t.Upstream.RoundTrip(req)
+	if err != nil {
+		return response, err
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if recordErr := t.record(req, response); recordErr != nil {
+		return response, recordErr
+	}
+	return response, nil
+}
+
+func (t *RecordModeTransport) record(req *http.Request, resp *http.Response) error {
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+
+	fixture := Fixture{
+		Request: FixtureRequest{
+			Method: req.Method,
+			URL:    req.URL.String(),
+		},
+		Response: FixtureResponse{
+			Status: resp.StatusCode,
+			Body:   string(bodyBytes),
+		},
+	}
+	t.cassette = append(t.cassette, fixture)
+	return writeCassette(t.CassettePath, t.cassette)
+}
+
+func loadCassette(path string) ([]Fixture, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil, nil
+	}
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures []Fixture
+	if err := json.Unmarshal(contents, &fixtures); err != nil {
+		return nil, err
+	}
+	return fixtures, nil
+}
+
+func writeCassette(path string, fixtures []Fixture) error {
+	contents, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, contents, 0644)
+}
+
+func findCassetteFixture(fixtures []Fixture, req *http.Request) (Fixture, bool) {
+	for _, fixture := range fixtures {
+		if fixture.Request.Method == req.Method && fixture.Request.URL == req.URL.String() {
+			return fixture, true
+		}
+	}
+	return Fixture{}, false
+}
@@ -0,0 +1,97 @@
+package request
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecordModeTransportRecordsThenReplays(t *testing.T) {
+	var serverHits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverHits++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("live"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "go-request-cassette")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cassettePath := filepath.Join(dir, "cassette.json")
+
+	transport := NewRecordModeTransport(cassettePath, nil)
+	client := &http.Client{Transport: transport}
+
+	res, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "live" {
+		t.Fatalf("expected first call to hit the live server, got body %q", body)
+	}
+	if serverHits != 1 {
+		t.Fatalf("expected 1 server hit after first call, got %d", serverHits)
+	}
+	if _, err := os.Stat(cassettePath); err != nil {
+		t.Fatalf("expected cassette file to be written: %v", err)
+	}
+
+	res, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ = ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "live" {
+		t.Fatalf("expected replayed body %q, got %q", "live", body)
+	}
+	if serverHits != 1 {
+		t.Fatalf("expected second call to replay from the cassette without hitting the server, server hits = %d", serverHits)
+	}
+}
+
+func TestRecordModeTransportReplaysFromExistingCassette(t *testing.T) {
+	var serverHits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		serverHits++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("should not be called"))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "go-request-cassette")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+	cassettePath := filepath.Join(dir, "cassette.json")
+
+	cassette := `[{"request": {"method": "GET", "url": "` + server.URL + `/"}, "response": {"status": 200, "body": "cassette"}}]`
+	if err := ioutil.WriteFile(cassettePath, []byte(cassette), 0644); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	transport := NewRecordModeTransport(cassettePath, nil)
+	client := &http.Client{Transport: transport}
+
+	res, err := client.Get(server.URL + "/")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	body, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if string(body) != "cassette" {
+		t.Fatalf("expected replayed cassette body, got %q", body)
+	}
+	if serverHits != 0 {
+		t.Fatalf("expected the server to never be hit, server hits = %d", serverHits)
+	}
+}
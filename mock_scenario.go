@@ -0,0 +1,116 @@
+package request
+
+import (
+	"math"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// MockSequence registers a responder on `DefaultMocker` that advances through `responses` on each
+// call, one response per call, holding on the last entry once exhausted.
+func MockSequence(verb string, url string, responses ...MockedResponse) {
+	DefaultMocker.RegisterResponder(verb, url, sequenceGenerator(responses))
+}
+
+// MockFlaky registers a responder on `DefaultMocker` that returns `failure` every `failEvery`-th
+// call (1-indexed) and `success` otherwise, deterministically simulating an intermittently failing
+// dependency for exercising retry/backoff logic.
+func MockFlaky(verb string, url string, failEvery int, success MockedResponse, failure MockedResponse) {
+	DefaultMocker.RegisterResponder(verb, url, flakyGenerator(failEvery, success, failure))
+}
+
+// MockLatency registers a responder on `DefaultMocker` that returns `response` with its `Delay`
+// sampled from `dist` on each call.
+func MockLatency(verb string, url string, dist LatencyDist, response MockedResponse) {
+	DefaultMocker.RegisterResponder(verb, url, latencyGenerator(dist, response))
+}
+
+func sequenceGenerator(responses []MockedResponse) MockedResponseGenerator {
+	var lock sync.Mutex
+	next := 0
+	return func() MockedResponse {
+		lock.Lock()
+		defer lock.Unlock()
+
+		if len(responses) == 0 {
+			return MockedResponse{}
+		}
+		index := next
+		if index >= len(responses) {
+			index = len(responses) - 1
+		} else {
+			next++
+		}
+		return responses[index]
+	}
+}
+
+func flakyGenerator(failEvery int, success MockedResponse, failure MockedResponse) MockedResponseGenerator {
+	var lock sync.Mutex
+	calls := 0
+	return func() MockedResponse {
+		lock.Lock()
+		defer lock.Unlock()
+
+		calls++
+		if failEvery > 0 && calls%failEvery == 0 {
+			return failure
+		}
+		return success
+	}
+}
+
+func latencyGenerator(dist LatencyDist, response MockedResponse) MockedResponseGenerator {
+	return func() MockedResponse {
+		response.Delay = dist.Sample()
+		return response
+	}
+}
+
+// LatencyDist samples a `time.Duration` to use as a mocked response's `Delay`, letting
+// `MockLatency` simulate constant, uniform, or normally distributed network latency.
+type LatencyDist interface {
+	Sample() time.Duration
+}
+
+// ConstantLatency always samples `Duration`.
+type ConstantLatency struct {
+	Duration time.Duration
+}
+
+// Sample implements `LatencyDist`.
+func (c ConstantLatency) Sample() time.Duration {
+	return c.Duration
+}
+
+// UniformLatency samples uniformly from the inclusive range [Min, Max].
+type UniformLatency struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// Sample implements `LatencyDist`.
+func (u UniformLatency) Sample() time.Duration {
+	if u.Max <= u.Min {
+		return u.Min
+	}
+	spread := u.Max - u.Min
+	return u.Min + time.Duration(rand.Int63n(int64(spread)+1))
+}
+
+// NormalLatency samples from a normal distribution with the given `Mean` and `StdDev`, clamped
+// to be non-negative.
+type NormalLatency struct {
+	Mean   time.Duration
+	StdDev time.Duration
+}
+
+// Sample implements `LatencyDist`.
+func (n NormalLatency) Sample() time.Duration {
+	sample := float64(n.Mean) + rand.NormFloat64()*float64(n.StdDev)
+	if sample < 0 {
+		sample = 0
+	}
+	return time.Duration(math.Round(sample))
+}
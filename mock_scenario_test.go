@@ -0,0 +1,102 @@
+package request
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestMockSequenceThroughExecute(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterResponder("GET", workingURL.String(), sequenceGenerator([]MockedResponse{
+		{StatusCode: 200, ResponseBody: []byte("first")},
+		{StatusCode: 500, ResponseBody: []byte("second")},
+	}))
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithMocker(mocker)
+
+	meta, body, err := hr.Execute()
+	if err != nil || meta.StatusCode != 200 || string(body) != "first" {
+		t.Fatalf("expected first response, got status=%d body=%q err=%v", meta.StatusCode, body, err)
+	}
+
+	meta, body, err = hr.Execute()
+	if err != nil || meta.StatusCode != 500 || string(body) != "second" {
+		t.Fatalf("expected second response, got status=%d body=%q err=%v", meta.StatusCode, body, err)
+	}
+
+	meta, body, err = hr.Execute()
+	if err != nil || meta.StatusCode != 500 || string(body) != "second" {
+		t.Fatalf("expected sequence to hold on last response, got status=%d body=%q err=%v", meta.StatusCode, body, err)
+	}
+}
+
+func TestMockFlakyThroughExecute(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterResponder("GET", workingURL.String(), flakyGenerator(
+		2,
+		MockedResponse{StatusCode: 200, ResponseBody: []byte("ok")},
+		MockedResponse{StatusCode: 503, ResponseBody: []byte("unavailable")},
+	))
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithMocker(mocker)
+
+	for i, expected := range []int{200, 503, 200, 503} {
+		meta, _, err := hr.Execute()
+		if err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+		if meta.StatusCode != expected {
+			t.Fatalf("call %d: expected status %d, got %d", i, expected, meta.StatusCode)
+		}
+	}
+}
+
+func TestMockLatencyDelayIsHonored(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterResponder("GET", workingURL.String(), latencyGenerator(
+		ConstantLatency{Duration: 10 * time.Millisecond},
+		MockedResponse{StatusCode: 200, ResponseBody: []byte("ok")},
+	))
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithMocker(mocker)
+
+	start := time.Now()
+	if _, _, err := hr.Execute(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Fatalf("expected at least a 10ms delay, took %s", elapsed)
+	}
+}
+
+func TestMockLatencyDelayRespectsContextCancellationThroughExecute(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterResponder("GET", workingURL.String(), latencyGenerator(
+		ConstantLatency{Duration: time.Hour},
+		MockedResponse{StatusCode: 200, ResponseBody: []byte("ok")},
+	))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithMocker(mocker).WithContext(ctx)
+
+	start := time.Now()
+	_, _, err := hr.Execute()
+	if err == nil {
+		t.Fatalf("expected context cancellation error, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("expected the delay to be cut short by context cancellation, took %s", elapsed)
+	}
+}
@@ -0,0 +1,61 @@
+package request
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestMockerScopedToRequest(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	mocker := NewMocker()
+	mocker.RegisterResponder("GET", workingURL.String(), func() MockedResponse {
+		return MockedResponse{StatusCode: 201, ResponseBody: []byte("scoped")}
+	})
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL).WithMocker(mocker)
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 201 {
+		t.Fatalf("expected status 201, got %d", meta.StatusCode)
+	}
+	if string(body) != "scoped" {
+		t.Fatalf("expected body %q, got %q", "scoped", body)
+	}
+}
+
+func TestMockerFallsBackToDefaultMocker(t *testing.T) {
+	defer ClearMockedResponses()
+
+	workingURL, _ := url.Parse("http://example.com/widgets")
+	MockResponseFromString("GET", workingURL.String(), 200, "default")
+
+	hr := NewHTTPRequest().WithVerb("GET").WithURL(workingURL)
+	meta, body, err := hr.Execute()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if meta.StatusCode != 200 {
+		t.Fatalf("expected status 200, got %d", meta.StatusCode)
+	}
+	if string(body) != "default" {
+		t.Fatalf("expected body %q, got %q", "default", body)
+	}
+}
+
+func TestMockerDoesNotLeakBetweenInstances(t *testing.T) {
+	workingURL, _ := url.Parse("http://example.com/widgets")
+
+	a := NewMocker()
+	a.RegisterResponder("GET", workingURL.String(), func() MockedResponse {
+		return MockedResponse{StatusCode: 200, ResponseBody: []byte("a")}
+	})
+	b := NewMocker()
+
+	handled, _, _, _ := b.inject("GET", workingURL)
+	if handled {
+		t.Fatalf("expected b to have no mocks registered")
+	}
+}
@@ -0,0 +1,124 @@
+package request
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ResponseMeta is the metadata returned alongside a response body, for both real
+// and mocked calls.
+type ResponseMeta struct {
+	StatusCode    int
+	ContentLength int64
+	Headers       http.Header
+}
+
+// HTTPRequest is a fluent builder for, and executor of, a single outbound HTTP call.
+type HTTPRequest struct {
+	Verb     string
+	URL      *url.URL
+	Header   http.Header
+	PostBody []byte
+	Client   *http.Client
+	Context  context.Context
+
+	mocker *Mocker
+}
+
+// NewHTTPRequest returns a new `HTTPRequest` defaulting to a `GET`.
+func NewHTTPRequest() *HTTPRequest {
+	return &HTTPRequest{
+		Verb:   "GET",
+		Header: http.Header{},
+	}
+}
+
+// WithVerb sets the request verb (method).
+func (hr *HTTPRequest) WithVerb(verb string) *HTTPRequest {
+	hr.Verb = verb
+	return hr
+}
+
+// WithURL sets the request url.
+func (hr *HTTPRequest) WithURL(workingURL *url.URL) *HTTPRequest {
+	hr.URL = workingURL
+	return hr
+}
+
+// WithHeader sets a header value on the request.
+func (hr *HTTPRequest) WithHeader(key, value string) *HTTPRequest {
+	if hr.Header == nil {
+		hr.Header = http.Header{}
+	}
+	hr.Header.Set(key, value)
+	return hr
+}
+
+// WithPostBody sets the raw request body.
+func (hr *HTTPRequest) WithPostBody(body []byte) *HTTPRequest {
+	hr.PostBody = body
+	return hr
+}
+
+// WithClient sets the `http.Client` used for the real (non-mocked) network call.
+func (hr *HTTPRequest) WithClient(client *http.Client) *HTTPRequest {
+	hr.Client = client
+	return hr
+}
+
+// WithContext sets the context governing this request. Cancelling it cancels the real
+// network call and, per `Mocker.injectRequest`, any `MockedResponse.Delay` being waited out.
+func (hr *HTTPRequest) WithContext(ctx context.Context) *HTTPRequest {
+	hr.Context = ctx
+	return hr
+}
+
+func (hr *HTTPRequest) context() context.Context {
+	if hr.Context != nil {
+		return hr.Context
+	}
+	return context.Background()
+}
+
+// Execute sends the request, consulting a mocked response (a bound `Mocker` via
+// `WithMocker`, falling back to `DefaultMocker`) before making any real network call.
+func (hr *HTTPRequest) Execute() (*ResponseMeta, []byte, error) {
+	if mocked, meta, body, err := hr.mockedResponseInjector(); mocked {
+		return meta, body, err
+	}
+
+	req, err := http.NewRequest(hr.Verb, hr.URL.String(), bytes.NewReader(hr.PostBody))
+	if err != nil {
+		return nil, nil, err
+	}
+	req = req.WithContext(hr.context())
+	if hr.Header != nil {
+		req.Header = hr.Header
+	}
+
+	client := hr.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+
+	responseBody, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := &ResponseMeta{
+		StatusCode:    res.StatusCode,
+		ContentLength: res.ContentLength,
+		Headers:       res.Header,
+	}
+	return meta, responseBody, nil
+}